@@ -0,0 +1,352 @@
+package glance
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// mediaBrowserServerType distinguishes between the handful of MediaBrowser-protocol
+// servers we talk to. Jellyfin and Emby share most of the API surface but diverge
+// in small ways (timestamp formats, some field names), so widgets built on top of
+// mediaBrowserClient can branch on this where needed.
+type mediaBrowserServerType string
+
+const (
+	mediaBrowserServerTypeJellyfin mediaBrowserServerType = "jellyfin"
+	mediaBrowserServerTypeEmby     mediaBrowserServerType = "emby"
+)
+
+const mediaBrowserSessionsEndpoint = "Sessions?ActiveWithinSeconds=960"
+const mediaBrowserAuthorizationFmt = `MediaBrowser Token="%s"`
+
+const (
+	mediaBrowserClientName    = "glance"
+	mediaBrowserDeviceName    = "glance"
+	mediaBrowserClientVersion = "1.0.0"
+)
+
+// mediaBrowserClient holds everything needed to talk to a Jellyfin or Emby
+// server and is meant to be embedded by widgets that need to query one.
+//
+// Two auth modes are supported: a long-lived api-key, sent as a plain
+// `MediaBrowser Token="..."` Authorization header, or a username/password
+// login that's exchanged for a short-lived access token via
+// Users/AuthenticateByName and sent as a full X-Emby-Authorization header -
+// this is what makes the widget show up under the server's own
+// Dashboard -> Devices, and what 401s and transparently re-authenticates.
+type mediaBrowserClient struct {
+	ServerType mediaBrowserServerType
+	URL        string
+	ApiKey     string
+	Username   string
+	Password   string
+
+	mu          sync.Mutex
+	accessToken string
+	userID      string
+}
+
+func (client *mediaBrowserClient) usesLogin() bool {
+	return client.Username != ""
+}
+
+// deviceID is derived deterministically from the server URL and username so
+// that re-logins reuse the same device entry instead of spamming the
+// server's Dashboard -> Devices list with a new one on every restart.
+func (client *mediaBrowserClient) deviceID() string {
+	sum := sha1.Sum([]byte(client.URL + client.Username))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func (client *mediaBrowserClient) embyAuthorizationHeader(token string) string {
+	header := fmt.Sprintf(
+		`MediaBrowser Client="%s", Device="%s", DeviceId="%s", Version="%s"`,
+		mediaBrowserClientName, mediaBrowserDeviceName, client.deviceID(), mediaBrowserClientVersion,
+	)
+
+	if token != "" {
+		header += fmt.Sprintf(`, Token="%s"`, token)
+	}
+
+	return header
+}
+
+type mediaBrowserAuthResponse struct {
+	AccessToken string `json:"AccessToken"`
+	User        struct {
+		Id string `json:"Id"`
+	} `json:"User"`
+}
+
+// authenticate logs in with Username/Password and stores the returned access
+// token and user ID for subsequent requests.
+func (client *mediaBrowserClient) authenticate() error {
+	payload, err := json.Marshal(map[string]string{
+		"Username": client.Username,
+		"Pw":       client.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding credentials: %w", err)
+	}
+
+	request, err := http.NewRequest("POST", client.URL+"Users/AuthenticateByName", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Emby-Authorization", client.embyAuthorizationHeader(""))
+
+	auth, err := decodeJsonFromRequest[mediaBrowserAuthResponse](defaultHTTPClient, request)
+	if err != nil {
+		return fmt.Errorf("authenticating: %w", err)
+	}
+
+	client.mu.Lock()
+	client.accessToken = auth.AccessToken
+	client.userID = auth.User.Id
+	client.mu.Unlock()
+
+	return nil
+}
+
+// verifyCredentials checks a username/password pair against the server's own
+// Users/AuthenticateByName endpoint, without touching the client's stored
+// access token. It's used to authenticate control requests (pause/unpause/stop)
+// against a specific user rather than the widget's own service account.
+func (client *mediaBrowserClient) verifyCredentials(username, password string) (bool, error) {
+	payload, err := json.Marshal(map[string]string{
+		"Username": username,
+		"Pw":       password,
+	})
+	if err != nil {
+		return false, fmt.Errorf("encoding credentials: %w", err)
+	}
+
+	request, err := http.NewRequest("POST", client.URL+"Users/AuthenticateByName", bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Emby-Authorization", client.embyAuthorizationHeader(""))
+
+	response, err := defaultHTTPClient.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("verifying credentials: %w", err)
+	}
+	defer response.Body.Close()
+
+	return response.StatusCode >= 200 && response.StatusCode < 300, nil
+}
+
+func (client *mediaBrowserClient) currentAccessToken() string {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	return client.accessToken
+}
+
+func (client *mediaBrowserClient) setAuthHeader(request *http.Request) {
+	if client.usesLogin() {
+		request.Header.Set("X-Emby-Authorization", client.embyAuthorizationHeader(client.currentAccessToken()))
+	} else {
+		request.Header.Set("Authorization", fmt.Sprintf(mediaBrowserAuthorizationFmt, client.ApiKey))
+	}
+}
+
+// do sends a request built by buildRequest, retrying once after a fresh login
+// if the server responds 401 and we're in username/password mode. buildRequest
+// is called again on retry so the (possibly consumed) request body is rebuilt.
+// The returned response is guaranteed to have a 2xx status code; anything
+// else is turned into an error so callers never decode an error body as if
+// it were a successful, empty result.
+func (client *mediaBrowserClient) do(buildRequest func() (*http.Request, error)) (*http.Response, error) {
+	request, err := buildRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	client.setAuthHeader(request)
+
+	response, err := defaultHTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized && client.usesLogin() {
+		response.Body.Close()
+
+		if err := client.authenticate(); err != nil {
+			return nil, fmt.Errorf("re-authenticating after 401: %w", err)
+		}
+
+		request, err = buildRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		client.setAuthHeader(request)
+
+		response, err = defaultHTTPClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, fmt.Errorf("unexpected status %s: %s", response.Status, bytes.TrimSpace(body))
+	}
+
+	return response, nil
+}
+
+func (client *mediaBrowserClient) get(endpoint string) (*http.Response, error) {
+	if client.usesLogin() && client.currentAccessToken() == "" {
+		if err := client.authenticate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return client.do(func() (*http.Request, error) {
+		return http.NewRequest("GET", client.URL+endpoint, nil)
+	})
+}
+
+func (client *mediaBrowserClient) post(endpoint string, body []byte, contentType string) (*http.Response, error) {
+	if client.usesLogin() && client.currentAccessToken() == "" {
+		if err := client.authenticate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return client.do(func() (*http.Request, error) {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		request, err := http.NewRequest("POST", client.URL+endpoint, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+
+		return request, nil
+	})
+}
+
+// fetchSessions retrieves the list of active sessions from the server. The shape
+// of the response is identical between Jellyfin and Emby, so no per-server
+// handling is required here.
+func (client *mediaBrowserClient) fetchSessions() ([]mediaBrowserSessionResponse, error) {
+	response, err := client.get(mediaBrowserSessionsEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("fetching sessions: %w", err)
+	}
+	defer response.Body.Close()
+
+	var sessions []mediaBrowserSessionResponse
+	if err := json.NewDecoder(response.Body).Decode(&sessions); err != nil {
+		return nil, fmt.Errorf("fetching sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (client *mediaBrowserClient) sendPlaystateCommand(sessionId, command string) error {
+	response, err := client.post(fmt.Sprintf("Sessions/%s/Playing/%s", sessionId, command), nil, "")
+	if err != nil {
+		return fmt.Errorf("sending %s command: %w", command, err)
+	}
+	defer response.Body.Close()
+
+	return nil
+}
+
+// fetchLatestItems retrieves the most recently added library items for a user,
+// optionally filtered by item type and/or library (ParentId).
+func (client *mediaBrowserClient) fetchLatestItems(userId string, limit int, includeTypes string, parentId string) ([]mediaBrowserItemResponse, error) {
+	endpoint := fmt.Sprintf("Users/%s/Items/Latest?Limit=%d", userId, limit)
+	if includeTypes != "" {
+		endpoint += "&IncludeItemTypes=" + includeTypes
+	}
+	if parentId != "" {
+		endpoint += "&ParentId=" + parentId
+	}
+
+	response, err := client.get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("fetching latest items: %w", err)
+	}
+	defer response.Body.Close()
+
+	var items []mediaBrowserItemResponse
+	if err := json.NewDecoder(response.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("fetching latest items: %w", err)
+	}
+
+	return items, nil
+}
+
+// imageAuthParam returns the value to send as the `api_key` query param on
+// image requests. Jellyfin/Emby accept either a long-lived API key or a
+// user's access token there, so in username/password mode we fall back to
+// the token obtained from the last login instead of the (empty) ApiKey.
+func (client *mediaBrowserClient) imageAuthParam() string {
+	if client.usesLogin() {
+		return client.currentAccessToken()
+	}
+
+	return client.ApiKey
+}
+
+// primaryImageURL builds the URL for an item's primary image. User images
+// are built by userImageURL.
+func (client *mediaBrowserClient) primaryImageURL(itemId string, maxHeight int) string {
+	return fmt.Sprintf("%sItems/%s/Images/Primary?maxHeight=%d&api_key=%s", client.URL, itemId, maxHeight, client.imageAuthParam())
+}
+
+type mediaBrowserUserResponse struct {
+	Id              string `json:"Id"`
+	Name            string `json:"Name"`
+	PrimaryImageTag string `json:"PrimaryImageTag"`
+}
+
+func (client *mediaBrowserClient) fetchUser(userId string) (mediaBrowserUserResponse, error) {
+	response, err := client.get("Users/" + userId)
+	if err != nil {
+		return mediaBrowserUserResponse{}, fmt.Errorf("fetching user: %w", err)
+	}
+	defer response.Body.Close()
+
+	var user mediaBrowserUserResponse
+	if err := json.NewDecoder(response.Body).Decode(&user); err != nil {
+		return mediaBrowserUserResponse{}, fmt.Errorf("fetching user: %w", err)
+	}
+
+	return user, nil
+}
+
+// userImageURL builds the URL for a user's primary image. tag is optional and,
+// when present, is used for cache-busting the way Jellyfin/Emby expect.
+func (client *mediaBrowserClient) userImageURL(userId, tag string, maxHeight int) string {
+	url := fmt.Sprintf("%sUsers/%s/Images/Primary?maxHeight=%d&api_key=%s", client.URL, userId, maxHeight, client.imageAuthParam())
+	if tag != "" {
+		url += "&tag=" + tag
+	}
+
+	return url
+}
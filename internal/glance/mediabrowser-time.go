@@ -0,0 +1,27 @@
+package glance
+
+import (
+	"fmt"
+	"time"
+)
+
+// Jellyfin and Emby are inconsistent about how they format timestamps: the
+// trailing `Z`/offset is sometimes omitted and the fractional-second digit
+// count varies. time.Parse already tolerates any fractional-second digit
+// count as long as the layout ends in a timezone marker, so RFC3339Nano alone
+// covers every offset-aware variant we've seen (with or without fractional
+// seconds, 7-digit Emby-style included). What it won't parse is a naive
+// timestamp with no offset at all, which we then assume is UTC.
+const mediaBrowserNaiveTimeLayout = "2006-01-02T15:04:05.999999999"
+
+func parseMediaBrowserTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation(mediaBrowserNaiveTimeLayout, value, time.UTC); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized media browser timestamp format: %q", value)
+}
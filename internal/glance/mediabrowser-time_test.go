@@ -0,0 +1,59 @@
+package glance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMediaBrowserTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{
+			name:  "RFC3339",
+			input: "2023-01-01T12:00:00Z",
+			want:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "RFC3339Nano",
+			input: "2023-01-01T12:00:00.123456789Z",
+			want:  time.Date(2023, 1, 1, 12, 0, 0, 123456789, time.UTC),
+		},
+		{
+			name:  "Emby-style 7-digit fraction with offset",
+			input: "2023-01-01T12:00:00.1234567+00:00",
+			want:  time.Date(2023, 1, 1, 12, 0, 0, 123456700, time.UTC),
+		},
+		{
+			name:  "naive with 7-digit fraction, no offset",
+			input: "2023-01-01T12:00:00.1234567",
+			want:  time.Date(2023, 1, 1, 12, 0, 0, 123456700, time.UTC),
+		},
+		{
+			name:  "naive without fractional seconds",
+			input: "2023-01-01T12:00:00",
+			want:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMediaBrowserTime(tt.input)
+			if err != nil {
+				t.Fatalf("parseMediaBrowserTime(%q) returned error: %v", tt.input, err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("parseMediaBrowserTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMediaBrowserTimeInvalid(t *testing.T) {
+	if _, err := parseMediaBrowserTime("not a timestamp"); err == nil {
+		t.Fatal("expected an error for an unrecognized timestamp format, got nil")
+	}
+}
@@ -0,0 +1,293 @@
+package glance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"time"
+)
+
+var jellyseerrRequestsWidgetTemplate = mustParseTemplate("jellyseerr-requests.html", "widget-base.html")
+
+type jellyseerrRequestsWidget struct {
+	widgetBase    `yaml:",inline"`
+	JellyseerrURL string                  `yaml:"url"`
+	ApiKey        string                  `yaml:"api-key"`
+	Status        string                  `yaml:"status"`
+	Limit         int                     `yaml:"limit"`
+	CollapseAfter int                     `yaml:"collapse-after"`
+	AllowActions  bool                    `yaml:"allow-actions"`
+	AdminUsers    []string                `yaml:"admin-users"`
+	Requests      []jellyseerrRequestItem `yaml:"-"`
+	ActionNonce   string                  `yaml:"-"`
+}
+
+type jellyseerrRequestItem struct {
+	Id          int
+	Title       string
+	PosterURL   string
+	RequestedBy string
+	Status      string
+}
+
+func (widget *jellyseerrRequestsWidget) initialize() error {
+	widget.
+		withTitle("Jellyseerr Requests").
+		withTitleURL(widget.JellyseerrURL).
+		withCacheDuration(10 * time.Minute)
+
+	if widget.JellyseerrURL == "" || widget.ApiKey == "" {
+		return fmt.Errorf("missing Jellyseerr URL or API key")
+	}
+
+	if widget.JellyseerrURL[len(widget.JellyseerrURL)-1] == '/' {
+		widget.JellyseerrURL = widget.JellyseerrURL[:len(widget.JellyseerrURL)-1]
+	}
+
+	switch widget.Status {
+	case "":
+		widget.Status = "pending"
+	case "pending", "approved", "available", "all":
+	default:
+		return fmt.Errorf("invalid status %q, must be one of: pending, approved, available, all", widget.Status)
+	}
+
+	if widget.Limit <= 0 {
+		widget.Limit = 10
+	}
+
+	if widget.CollapseAfter == 0 || widget.CollapseAfter < -1 {
+		widget.CollapseAfter = 5
+	}
+
+	if widget.AllowActions {
+		nonce, err := generateMediaBrowserControlNonce()
+		if err != nil {
+			return fmt.Errorf("generating action nonce: %w", err)
+		}
+
+		widget.ActionNonce = nonce
+	}
+
+	return nil
+}
+
+func (widget *jellyseerrRequestsWidget) update(ctx context.Context) {
+	requests, err := fetchJellyseerrRequests(widget)
+	if err != nil {
+		widget.withError(err)
+		return
+	}
+
+	if !widget.canContinueUpdateAfterHandlingErr(err) {
+		return
+	}
+
+	widget.Requests = requests
+}
+
+func (widget *jellyseerrRequestsWidget) Render() template.HTML {
+	return widget.renderTemplate(widget, jellyseerrRequestsWidgetTemplate)
+}
+
+// isAdminUser reports whether username is allowed to approve/decline
+// requests. If AdminUsers is empty, anyone with valid Jellyseerr
+// credentials is allowed.
+func (widget *jellyseerrRequestsWidget) isAdminUser(username string) bool {
+	if len(widget.AdminUsers) == 0 {
+		return true
+	}
+
+	return slices.Contains(widget.AdminUsers, username)
+}
+
+// registerRoutes mounts the widget's request action endpoint on mux at
+// /api/widgets/jellyseerr-requests/{nonce}/{requestId}/{action}, where
+// {nonce} is widget.ActionNonce. The server must call this once per
+// configured widget instance alongside the equivalent calls for every other
+// widget that exposes one of these endpoints; that central wiring lives in
+// the app's top-level route setup, outside this widget file.
+func (widget *jellyseerrRequestsWidget) registerRoutes(mux *http.ServeMux) {
+	if !widget.AllowActions {
+		return
+	}
+
+	mux.HandleFunc("POST /api/widgets/jellyseerr-requests/{nonce}/{requestId}/{action}", widget.handleRequestAction)
+}
+
+// handleRequestAction proxies an approve/decline click to the Jellyseerr API,
+// keeping the API key server-side, and is registered on the server's mux by
+// registerRoutes. Callers must authenticate with HTTP Basic Auth using their
+// own Jellyseerr credentials; the username is checked against AdminUsers (if
+// set) and the password is verified against Jellyseerr itself before the
+// action is proxied.
+func (widget *jellyseerrRequestsWidget) handleRequestAction(w http.ResponseWriter, r *http.Request) {
+	if !widget.AllowActions {
+		http.Error(w, "actions are disabled for this widget", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.PathValue("nonce") != widget.ActionNonce {
+		http.Error(w, "invalid or expired nonce", http.StatusForbidden)
+		return
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="jellyseerr-requests"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if !widget.isAdminUser(username) {
+		http.Error(w, "user is not allowed to issue request actions", http.StatusForbidden)
+		return
+	}
+
+	if valid, err := verifyJellyseerrCredentials(widget.JellyseerrURL, username, password); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	} else if !valid {
+		w.Header().Set("WWW-Authenticate", `Basic realm="jellyseerr-requests"`)
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	requestId := r.PathValue("requestId")
+	action := r.PathValue("action")
+
+	if action != "approve" && action != "decline" {
+		http.Error(w, "unknown action", http.StatusBadRequest)
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/v1/request/%s/%s", widget.JellyseerrURL, requestId, action)
+
+	proxyRequest, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	proxyRequest.Header.Add("X-Api-Key", widget.ApiKey)
+
+	response, err := defaultHTTPClient.Do(proxyRequest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer response.Body.Close()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyJellyseerrCredentials checks a username/password pair against
+// Jellyseerr's own local auth endpoint, the same one its login page uses.
+func verifyJellyseerrCredentials(baseURL, username, password string) (bool, error) {
+	payload, err := json.Marshal(map[string]string{
+		"email":    username,
+		"password": password,
+	})
+	if err != nil {
+		return false, fmt.Errorf("encoding credentials: %w", err)
+	}
+
+	request, err := http.NewRequest("POST", baseURL+"/api/v1/auth/local", bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := defaultHTTPClient.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("verifying credentials: %w", err)
+	}
+	defer response.Body.Close()
+
+	return response.StatusCode >= 200 && response.StatusCode < 300, nil
+}
+
+type jellyseerrRequestMedia struct {
+	PosterPath string `json:"posterPath"`
+	Title      string `json:"title"`
+	Name       string `json:"name"`
+}
+
+type jellyseerrRequestResponse struct {
+	Id          int                    `json:"id"`
+	Status      int                    `json:"status"`
+	Media       jellyseerrRequestMedia `json:"media"`
+	RequestedBy struct {
+		DisplayName string `json:"displayName"`
+	} `json:"requestedBy"`
+}
+
+type jellyseerrRequestsResponse struct {
+	Results []jellyseerrRequestResponse `json:"results"`
+}
+
+const (
+	jellyseerrStatusPending   = 1
+	jellyseerrStatusApproved  = 2
+	jellyseerrStatusAvailable = 3
+)
+
+func jellyseerrStatusLabel(status int) string {
+	switch status {
+	case jellyseerrStatusPending:
+		return "Pending"
+	case jellyseerrStatusApproved:
+		return "Approved"
+	case jellyseerrStatusAvailable:
+		return "Available"
+	default:
+		return "Unknown"
+	}
+}
+
+func fetchJellyseerrRequests(widget *jellyseerrRequestsWidget) ([]jellyseerrRequestItem, error) {
+	requestURL := fmt.Sprintf("%s/api/v1/request?take=%d&filter=%s&sort=added", widget.JellyseerrURL, widget.Limit, widget.Status)
+
+	request, _ := http.NewRequest("GET", requestURL, nil)
+	request.Header.Add("X-Api-Key", widget.ApiKey)
+
+	response, err := decodeJsonFromRequest[jellyseerrRequestsResponse](defaultHTTPClient, request)
+	if err != nil {
+		return nil, fmt.Errorf("fetching Jellyseerr requests: %w", err)
+	}
+
+	result := make([]jellyseerrRequestItem, 0, len(response.Results))
+
+	for i := range response.Results {
+		media := response.Results[i].Media
+
+		title := media.Title
+		if title == "" {
+			title = media.Name
+		}
+
+		posterURL := ""
+		if media.PosterPath != "" {
+			posterURL = "https://image.tmdb.org/t/p/w300" + media.PosterPath
+		}
+
+		result = append(result, jellyseerrRequestItem{
+			Id:          response.Results[i].Id,
+			Title:       title,
+			PosterURL:   posterURL,
+			RequestedBy: response.Results[i].RequestedBy.DisplayName,
+			Status:      jellyseerrStatusLabel(response.Results[i].Status),
+		})
+	}
+
+	return result, nil
+}
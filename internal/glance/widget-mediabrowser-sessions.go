@@ -0,0 +1,391 @@
+package glance
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+var mediaBrowserSessionsWidgetTemplate = mustParseTemplate("mediabrowser-sessions.html", "widget-base.html")
+
+type mediaBrowserSessionsWidget struct {
+	widgetBase          `yaml:",inline"`
+	ServerType          mediaBrowserServerType                `yaml:"type"`
+	JellyfinURL         string                                `yaml:"url"`
+	JellyfinApiKey      string                                `yaml:"api-key"`
+	Username            string                                `yaml:"username"`
+	Password            string                                `yaml:"password"`
+	HideUndefinedUsers  bool                                  `yaml:"hide-undefined-users"`
+	DefinedDisplayNames map[string]string                     `yaml:"display-names"`
+	CollapseAfter       int                                   `yaml:"collapse-after"`
+	AllowControls       bool                                  `yaml:"allow-controls"`
+	AdminUsers          []string                              `yaml:"admin-users"`
+	HideAvatars         bool                                  `yaml:"hide-avatars"`
+	AvatarSize          int                                   `yaml:"avatar-size"`
+	Sessions            []mediaBrowserSession                 `yaml:"-"`
+	ControlNonce        string                                `yaml:"-"`
+	client              mediaBrowserClient                    `yaml:"-"`
+	userCacheMu         sync.Mutex                            `yaml:"-"`
+	userCache           map[string]mediaBrowserUserCacheEntry `yaml:"-"`
+}
+
+type mediaBrowserSession struct {
+	SessionId       string
+	DisplayName     string
+	DeviceName      string
+	Client          string
+	UserId          string
+	IsNowPlaying    bool
+	PlayMethod      string
+	NowPlaying      string
+	Runtime         string
+	RuntimeProgress int
+	MediaId         string
+	AvatarURL       string
+	AccentColor     string
+	LastActivity    time.Time
+}
+
+type mediaBrowserUserCacheEntry struct {
+	avatarURL string
+	hasImage  bool
+	expiresAt time.Time
+}
+
+const mediaBrowserUserCacheTTL = 15 * time.Minute
+
+type mediaBrowserSessionList []mediaBrowserSession
+
+func (widget *mediaBrowserSessionsWidget) initialize() error {
+	switch widget.ServerType {
+	case "":
+		widget.ServerType = mediaBrowserServerTypeJellyfin
+	case mediaBrowserServerTypeJellyfin, mediaBrowserServerTypeEmby:
+	default:
+		return fmt.Errorf("invalid media browser server type %q, must be one of: jellyfin, emby", widget.ServerType)
+	}
+
+	if widget.ServerType == mediaBrowserServerTypeEmby {
+		widget.withTitle("Emby Sessions")
+	} else {
+		widget.withTitle("Jellyfin Sessions")
+	}
+
+	widget.
+		withTitleURL(widget.JellyfinURL).
+		withCacheDuration(0)
+
+	if widget.CollapseAfter == 0 || widget.CollapseAfter < -1 {
+		widget.CollapseAfter = 5
+	}
+
+	if widget.AvatarSize <= 0 {
+		widget.AvatarSize = 64
+	}
+
+	if widget.JellyfinURL == "" {
+		return fmt.Errorf("missing server URL")
+	}
+
+	if widget.JellyfinApiKey == "" && (widget.Username == "" || widget.Password == "") {
+		return fmt.Errorf("missing API key or username/password")
+	}
+
+	if widget.JellyfinURL[len(widget.JellyfinURL)-1] != '/' {
+		widget.JellyfinURL += "/"
+	}
+
+	widget.client = mediaBrowserClient{
+		ServerType: widget.ServerType,
+		URL:        widget.JellyfinURL,
+		ApiKey:     widget.JellyfinApiKey,
+		Username:   widget.Username,
+		Password:   widget.Password,
+	}
+
+	if widget.AllowControls {
+		nonce, err := generateMediaBrowserControlNonce()
+		if err != nil {
+			return fmt.Errorf("generating control nonce: %w", err)
+		}
+
+		widget.ControlNonce = nonce
+	}
+
+	return nil
+}
+
+func generateMediaBrowserControlNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// resolveAvatar returns the avatar URL for a user, fetching and memoizing the
+// user's PrimaryImageTag for mediaBrowserUserCacheTTL so we don't hit the
+// server on every refresh cycle. hasImage is false if the user has no avatar
+// set, in which case the caller should fall back to accentColorForUser.
+func (widget *mediaBrowserSessionsWidget) resolveAvatar(userId string) (avatarURL string, hasImage bool) {
+	widget.userCacheMu.Lock()
+	defer widget.userCacheMu.Unlock()
+
+	if entry, ok := widget.userCache[userId]; ok && time.Now().Before(entry.expiresAt) {
+		return entry.avatarURL, entry.hasImage
+	}
+
+	user, err := widget.client.fetchUser(userId)
+	hasImage = err == nil && user.PrimaryImageTag != ""
+	if hasImage {
+		avatarURL = widget.client.userImageURL(userId, user.PrimaryImageTag, widget.AvatarSize)
+	}
+
+	if widget.userCache == nil {
+		widget.userCache = make(map[string]mediaBrowserUserCacheEntry)
+	}
+
+	widget.userCache[userId] = mediaBrowserUserCacheEntry{
+		avatarURL: avatarURL,
+		hasImage:  hasImage,
+		expiresAt: time.Now().Add(mediaBrowserUserCacheTTL),
+	}
+
+	return avatarURL, hasImage
+}
+
+// accentColorForUser derives a stable HSL color from a user's ID, used as a
+// fallback avatar for users without a primary image set.
+func accentColorForUser(userId string) string {
+	h := fnv.New32a()
+	h.Write([]byte(userId))
+
+	hue := h.Sum32() % 360
+
+	return fmt.Sprintf("hsl(%d, 65%%, 50%%)", hue)
+}
+
+// isAdminUser reports whether the given Jellyfin/Emby username is allowed to
+// issue playback commands. An empty admin-users list means any user with
+// valid Jellyfin/Emby credentials is allowed.
+func (widget *mediaBrowserSessionsWidget) isAdminUser(username string) bool {
+	if len(widget.AdminUsers) == 0 {
+		return true
+	}
+
+	return slices.Contains(widget.AdminUsers, username)
+}
+
+// registerRoutes mounts the widget's session control endpoint on mux at
+// /api/widgets/mediabrowser-sessions/{nonce}/{sessionId}/{action}, where
+// {nonce} is widget.ControlNonce. The server must call this once per
+// configured widget instance alongside the equivalent calls for every other
+// widget that exposes one of these endpoints; that central wiring lives in
+// the app's top-level route setup, outside this widget file.
+func (widget *mediaBrowserSessionsWidget) registerRoutes(mux *http.ServeMux) {
+	if !widget.AllowControls {
+		return
+	}
+
+	mux.HandleFunc("POST /api/widgets/mediabrowser-sessions/{nonce}/{sessionId}/{action}", widget.handleSessionControl)
+}
+
+// handleSessionControl handles the pause/unpause/stop requests issued from
+// the session control buttons rendered in the widget template, and is
+// registered on the server's mux by registerRoutes. {nonce} is
+// widget.ControlNonce - this both scopes the request to this widget instance
+// and keeps the endpoint unguessable on a LAN dashboard. Callers must
+// authenticate with HTTP Basic Auth using their own Jellyfin/Emby
+// credentials; the username is checked against AdminUsers (if set) and the
+// password is verified against the server itself before any command runs.
+func (widget *mediaBrowserSessionsWidget) handleSessionControl(w http.ResponseWriter, r *http.Request) {
+	if !widget.AllowControls {
+		http.Error(w, "controls are disabled for this widget", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.PathValue("nonce") != widget.ControlNonce {
+		http.Error(w, "invalid or expired nonce", http.StatusForbidden)
+		return
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="mediabrowser-sessions"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if !widget.isAdminUser(username) {
+		http.Error(w, "user is not allowed to issue session controls", http.StatusForbidden)
+		return
+	}
+
+	if valid, err := widget.client.verifyCredentials(username, password); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	} else if !valid {
+		w.Header().Set("WWW-Authenticate", `Basic realm="mediabrowser-sessions"`)
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	sessionId := r.PathValue("sessionId")
+	action := r.PathValue("action")
+
+	if sessionId == "" || action == "" {
+		http.Error(w, "missing sessionId or action", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+
+	switch action {
+	case "pause":
+		err = widget.client.sendPlaystateCommand(sessionId, "Pause")
+	case "unpause":
+		err = widget.client.sendPlaystateCommand(sessionId, "Unpause")
+	case "stop":
+		err = widget.client.sendPlaystateCommand(sessionId, "Stop")
+	default:
+		http.Error(w, "unknown action", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (widget *mediaBrowserSessionsWidget) update(ctx context.Context) {
+	sessions, err := fetchMediaBrowserSessions(widget)
+	if err != nil {
+		widget.withError(err)
+		return
+	}
+
+	if !widget.canContinueUpdateAfterHandlingErr(err) {
+		return
+	}
+
+	widget.Sessions = sessions
+}
+
+func (widget *mediaBrowserSessionsWidget) Render() template.HTML {
+	return widget.renderTemplate(widget, mediaBrowserSessionsWidgetTemplate)
+}
+
+type mediaBrowserPlayState struct {
+	PositionTicks int64  `json:"PositionTicks,omitempty"`
+	PlayMethod    string `json:"PlayMethod,omitempty"`
+	MediaSourceId string `json:"MediaSourceId,omitempty"`
+}
+
+type mediaBrowserNowPlayingItem struct {
+	Name         string `json:"Name"`
+	Type         string `json:"Type"`
+	SeasonName   string `json:"SeasonName"`
+	SeriesName   string `json:"SeriesName"`
+	RuntimeTicks int64  `json:"RuntimeTicks"`
+}
+
+type mediaBrowserSessionResponse struct {
+	PlayState        mediaBrowserPlayState      `json:"PlayState"`
+	UserName         string                     `json:"UserName"`
+	Client           string                     `json:"Client"`
+	DeviceName       string                     `json:"DeviceName"`
+	UserId           string                     `json:"UserId"`
+	SessionId        string                     `json:"SessionId"`
+	LastActivityDate string                     `json:"LastActivityDate"`
+	NowPlayingItem   mediaBrowserNowPlayingItem `json:"NowPlayingItem"`
+}
+
+func fetchMediaBrowserSessions(widget *mediaBrowserSessionsWidget) (mediaBrowserSessionList, error) {
+	result := make(mediaBrowserSessionList, 0)
+
+	response, err := widget.client.fetchSessions()
+	if err != nil {
+		return result, fmt.Errorf("fetching sessions: %w", err)
+	}
+
+	for i := range response {
+		displayName, ok := widget.DefinedDisplayNames[response[i].UserName]
+		if !ok && widget.HideUndefinedUsers {
+			// Skipping undefined user
+			continue
+		}
+
+		if !ok {
+			displayName = response[i].UserName
+		}
+
+		isNowPlaying := response[i].NowPlayingItem.Name != ""
+		runtimeProgress := 0
+		nowPlaying := ""
+		runtime := ""
+
+		if isNowPlaying {
+			runtimeProgress = int(float64(response[i].PlayState.PositionTicks) / float64(response[i].NowPlayingItem.RuntimeTicks) * 100)
+			// Convert PositionTicks (100-nanosecond intervals) to h:m:s
+			positionTime := time.Duration(response[i].PlayState.PositionTicks * 100) // Convert to nanoseconds
+			runtime = fmt.Sprintf("%02d:%02d:%02d", int(positionTime.Hours()), int(positionTime.Minutes())%60, int(positionTime.Seconds())%60)
+		}
+
+		switch response[i].NowPlayingItem.Type {
+		case "Episode":
+			nowPlaying = fmt.Sprintf("%s - %s (%s)", response[i].NowPlayingItem.SeriesName, response[i].NowPlayingItem.Name, response[i].NowPlayingItem.SeasonName)
+		case "Movie":
+			nowPlaying = response[i].NowPlayingItem.Name
+		default:
+			nowPlaying = response[i].NowPlayingItem.Name
+		}
+
+		lastActivity, err := parseMediaBrowserTime(response[i].LastActivityDate)
+		if err != nil {
+			lastActivity = time.Time{}
+		}
+
+		session := mediaBrowserSession{
+			SessionId:       response[i].SessionId,
+			LastActivity:    lastActivity,
+			DisplayName:     displayName,
+			DeviceName:      response[i].DeviceName,
+			Client:          response[i].Client,
+			UserId:          response[i].UserId,
+			IsNowPlaying:    isNowPlaying,
+			PlayMethod:      response[i].PlayState.PlayMethod,
+			NowPlaying:      nowPlaying,
+			Runtime:         runtime,
+			RuntimeProgress: runtimeProgress,
+			MediaId:         response[i].PlayState.MediaSourceId,
+		}
+
+		if !widget.HideAvatars {
+			if avatarURL, hasImage := widget.resolveAvatar(response[i].UserId); hasImage {
+				session.AvatarURL = avatarURL
+			} else {
+				session.AccentColor = accentColorForUser(response[i].UserId)
+			}
+		}
+
+		result = append(result, session)
+	}
+
+	return result, nil
+}